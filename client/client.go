@@ -0,0 +1,133 @@
+// Package client talks to a task-tracker server's REST API, implementing
+// storage.Repository so the CLI commands can run unmodified against either
+// a local file or a shared server.
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/EssaAlshammri/task-tracker/storage"
+)
+
+// Client is a storage.Repository backed by a task-tracker server.
+type Client struct {
+	baseURL string
+	http    *http.Client
+}
+
+// New builds a Client against the server at baseURL (e.g. "http://host:8080").
+func New(baseURL string) *Client {
+	return &Client{baseURL: baseURL, http: &http.Client{}}
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// do sends an HTTP request and decodes a JSON response into out (if out is
+// non-nil), translating a 404 into storage.ErrTaskNotFound and any other
+// non-2xx status into an error carrying the server's message.
+func (c *Client) do(method, path string, body, out any) error {
+	var reqBody bytes.Buffer
+	if body != nil {
+		if err := json.NewEncoder(&reqBody).Encode(body); err != nil {
+			return fmt.Errorf("encode request: %w", err)
+		}
+	}
+
+	req, err := http.NewRequest(method, c.baseURL+path, &reqBody)
+	if err != nil {
+		return fmt.Errorf("build request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s %s: %w", method, path, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return storage.ErrTaskNotFound
+	}
+	if resp.StatusCode >= 300 {
+		var errResp errorResponse
+		json.NewDecoder(resp.Body).Decode(&errResp)
+		if errResp.Error != "" {
+			return fmt.Errorf("%s %s: %s", method, path, errResp.Error)
+		}
+		return fmt.Errorf("%s %s: unexpected status %d", method, path, resp.StatusCode)
+	}
+
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+type addRequest struct {
+	Description string     `json:"description"`
+	Priority    string     `json:"priority,omitempty"`
+	Tags        []string   `json:"tags,omitempty"`
+	DueAt       *time.Time `json:"dueAt,omitempty"`
+}
+
+func (c *Client) Add(description string, input storage.TaskInput) (*storage.Task, error) {
+	var task storage.Task
+	req := addRequest{Description: description, Priority: input.Priority, Tags: input.Tags, DueAt: input.DueAt}
+	err := c.do(http.MethodPost, "/tasks", req, &task)
+	return &task, err
+}
+
+func (c *Client) Update(id int, description string) error {
+	return c.do(http.MethodPatch, fmt.Sprintf("/tasks/%d", id), map[string]string{"description": description}, nil)
+}
+
+func (c *Client) Delete(id int) error {
+	return c.do(http.MethodDelete, fmt.Sprintf("/tasks/%d", id), nil, nil)
+}
+
+func (c *Client) MarkInProgress(id int) error {
+	return c.do(http.MethodPost, fmt.Sprintf("/tasks/%d/in-progress", id), nil, nil)
+}
+
+func (c *Client) MarkDone(id int) error {
+	return c.do(http.MethodPost, fmt.Sprintf("/tasks/%d/done", id), nil, nil)
+}
+
+func (c *Client) List(q storage.Query) ([]storage.Task, error) {
+	params := url.Values{}
+	if q.Status != "" {
+		params.Set("status", q.Status)
+	}
+	if q.Tag != "" {
+		params.Set("tag", q.Tag)
+	}
+	if len(q.Sort) > 0 {
+		params.Set("sort", strings.Join(q.Sort, ","))
+	}
+	if q.DueBefore != nil {
+		params.Set("due_before", q.DueBefore.Format("2006-01-02"))
+	}
+
+	path := "/tasks"
+	if encoded := params.Encode(); encoded != "" {
+		path += "?" + encoded
+	}
+
+	var tasks []storage.Task
+	err := c.do(http.MethodGet, path, nil, &tasks)
+	return tasks, err
+}
+
+// Close is a no-op: the client holds no resources between requests beyond
+// the stdlib's pooled HTTP connections.
+func (c *Client) Close() error {
+	return nil
+}
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/EssaAlshammri/task-tracker/storage"
+)
+
+// CLI-level sentinel errors. exitCodeFor matches them with errors.Is to
+// choose a process exit code distinct from "unexpected failure", so
+// scripts can tell usage mistakes, missing tasks, and storage failures
+// apart without scraping stderr.
+var (
+	ErrInvalidID = errors.New("invalid id")
+	ErrStorage   = errors.New("storage error")
+)
+
+// wrapRepoErr classifies an error returned by the storage layer: sentinel
+// errors the CLI already understands pass through untouched, and anything
+// else (disk I/O, driver failures) is wrapped in ErrStorage so callers can
+// distinguish "your request was bad" from "the backend is unhappy".
+func wrapRepoErr(err error) error {
+	if err == nil {
+		return nil
+	}
+	if errors.Is(err, storage.ErrTaskNotFound) || errors.Is(err, storage.ErrInvalidStatus) {
+		return err
+	}
+	return fmt.Errorf("%w: %w", ErrStorage, err)
+}
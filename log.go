@@ -0,0 +1,19 @@
+package main
+
+import (
+	"io"
+	"os"
+
+	"github.com/rs/zerolog"
+)
+
+// newLogger builds the CLI's logger: a human-readable console writer on
+// stderr by default, or newline-delimited JSON when format is "json" so
+// output stays parseable when task-tracker is run from a script.
+func newLogger(format string) zerolog.Logger {
+	var w io.Writer = zerolog.ConsoleWriter{Out: os.Stderr}
+	if format == "json" {
+		w = os.Stderr
+	}
+	return zerolog.New(w).With().Timestamp().Logger()
+}
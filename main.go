@@ -1,275 +1,628 @@
 package main
 
 import (
+	"encoding/csv"
 	"encoding/json"
 	"errors"
+	"flag"
 	"fmt"
 	"os"
 	"slices"
 	"strconv"
+	"strings"
+	"text/tabwriter"
 	"time"
+
+	"github.com/EssaAlshammri/task-tracker/client"
+	"github.com/EssaAlshammri/task-tracker/server"
+	"github.com/EssaAlshammri/task-tracker/storage"
+	"github.com/EssaAlshammri/task-tracker/sync"
+	"github.com/rs/zerolog"
 )
 
-type Task struct {
-	ID          int       `json:"id"`
-	Description string    `json:"description"`
-	Status      string    `json:"status"`
-	CreatedAt   time.Time `json:"createdAt"`
-	UpdatedAt   time.Time `json:"updatedAt"`
+// dateLayout is the expected format for --due and --due-before flags.
+const dateLayout = "2006-01-02"
+
+// storePath returns the on-disk path for a given backend when the user
+// hasn't overridden it: the JSON document keeps its historical name so
+// existing installs keep working, while the embedded drivers get their own
+// files.
+func storePath(backend string) string {
+	switch backend {
+	case storage.BackendSQLite:
+		return "tasks.db"
+	case storage.BackendBolt:
+		return "tasks.bolt"
+	default:
+		return "tasks.json"
+	}
 }
 
-type TaskRepository interface {
-	Add(description string) (*Task, error)
-	Update(id int, description string) error
-	Delete(id int) error
-	MarkInProgress(id int) error
-	MarkDone(id int) error
-	List(status string) ([]Task, error)
+func backendFromEnv() string {
+	if b := os.Getenv("TASKS_BACKEND"); b != "" {
+		return b
+	}
+	return storage.BackendJSON
 }
 
-type JsonTaskRepository struct {
-	filename string
-	tasks    []Task
-}
+func main() {
+	args := os.Args[1:]
+
+	backend, args := extractStoreFlag(args, backendFromEnv())
+	serverURL, args := extractServerFlag(args)
+	logFormat, args := extractLogFormatFlag(args)
+	idFormat, args := extractIDFormatFlag(args)
+
+	if len(args) < 1 {
+		printUsage()
+		os.Exit(1)
+	}
+	command := args[0]
+	args = args[1:]
+
+	logger := newLogger(logFormat)
 
-func newJsonTaskRepository(filename string) (*JsonTaskRepository, error) {
-	repo := &JsonTaskRepository{
-		filename: filename,
+	if command == "migrate" {
+		if err := migrate(args); err != nil {
+			logger.Error().Err(err).Msg("migrate failed")
+			os.Exit(1)
+		}
+		return
 	}
-	if err := repo.load(); err != nil {
-		return nil, err
+
+	if command == "sync" {
+		if err := runSync(); err != nil {
+			logger.Error().Err(err).Msg("sync failed")
+			os.Exit(1)
+		}
+		return
+	}
+
+	if command == "serve" {
+		if err := serve(args, backend, idFormat); err != nil {
+			logger.Error().Err(err).Msg("serve failed")
+			os.Exit(1)
+		}
+		return
 	}
-	return repo, nil
-}
 
-func (r *JsonTaskRepository) load() error {
-	file, err := os.ReadFile(r.filename)
-	if os.IsNotExist(err) {
-		emptyTasks := []Task{}
-		data, err := json.Marshal(emptyTasks)
+	var repo storage.Repository
+	var dispatcher *sync.Dispatcher
+	if serverURL != "" {
+		repo = client.New(serverURL)
+	} else {
+		var err error
+		repo, err = storage.Open(backend, storePath(backend), idFormat)
 		if err != nil {
-			return err
+			logger.Error().Err(err).Msg("failed to initialize repository")
+			os.Exit(1)
 		}
-		return os.WriteFile(r.filename, data, 0644)
+		dispatcher = dialDispatcher()
 	}
+	defer repo.Close()
 
-	return json.Unmarshal(file, &r.tasks)
-}
+	var err error
+	switch command {
+	case "add":
+		err = add(args, repo, dispatcher, logger)
+	case "update":
+		err = update(args, repo, dispatcher, logger)
+	case "delete":
+		err = delete(args, repo, dispatcher, logger)
+	case "mark-in-progress":
+		err = markInProgress(args, repo, dispatcher, logger)
+	case "mark-done":
+		err = markDone(args, repo, dispatcher, logger)
+	case "list":
+		err = list(args, repo)
+	default:
+		printUsage()
+		os.Exit(1)
+	}
 
-func (r *JsonTaskRepository) save() error {
-	data, err := json.Marshal(r.tasks)
 	if err != nil {
-		return fmt.Errorf("failed to marshal tasks: %w", err)
+		logger.Error().Err(err).Msg("command failed")
+		os.Exit(exitCodeFor(err))
 	}
-	return os.WriteFile(r.filename, data, 0644)
-
 }
 
-func (r *JsonTaskRepository) List(status string) ([]Task, error) {
-	if status != "" {
-		var filteredTasks []Task
-		for _, t := range r.tasks {
-			if t.Status == status {
-				filteredTasks = append(filteredTasks, t)
-			}
-		}
-		return filteredTasks, nil
+// exitCodeFor maps a command error to a distinct process exit code, so
+// scripts can tell "no such task" apart from other failures without
+// scraping stderr.
+func exitCodeFor(err error) int {
+	switch {
+	case errors.Is(err, ErrInvalidID), errors.Is(err, storage.ErrInvalidStatus):
+		return 2
+	case errors.Is(err, storage.ErrTaskNotFound):
+		return 3
+	case errors.Is(err, ErrStorage):
+		return 4
+	default:
+		return 1
 	}
-	return r.tasks, nil
 }
 
-func (r *JsonTaskRepository) Add(description string) (*Task, error) {
-
-	task := Task{
-		ID:          len(r.tasks) + 1,
-		Description: description,
-		Status:      "todo",
-		CreatedAt:   time.Now().UTC(),
-		UpdatedAt:   time.Now().UTC(),
+// dialDispatcher loads the optional sync config and builds a Dispatcher so
+// local mutations can be mirrored to other devices. Sync is opt-in: when no
+// config file exists, it returns nil and callers skip dispatch silently.
+func dialDispatcher() *sync.Dispatcher {
+	path, err := sync.ConfigPath()
+	if err != nil {
+		return nil
 	}
-	r.tasks = append(r.tasks, task)
-
-	return &task, r.save()
+	cfg, err := sync.LoadConfig(path)
+	if err != nil {
+		return nil
+	}
+	return sync.NewDispatcher(cfg)
+}
 
+// dispatch mirrors a local mutation to other devices when sync is
+// configured. A send failure is reported but doesn't fail the command,
+// since the mutation already succeeded locally.
+func dispatch(d *sync.Dispatcher, u storage.Update, logger zerolog.Logger) {
+	if d == nil {
+		return
+	}
+	if err := d.Send(u); err != nil {
+		logger.Warn().Err(err).Msg("failed to sync update")
+	}
 }
 
-func (r *JsonTaskRepository) Update(id int, description string) error {
-	for i, t := range r.tasks {
-		if t.ID == id {
-			r.tasks[i].Description = description
-			r.tasks[i].UpdatedAt = time.Now().UTC()
+// extractStoreFlag pulls the first "--store=<backend>" (or "--store
+// <backend>") out of args, wherever it appears, returning the resolved backend name and the
+// remaining args. def is used when no flag is present.
+func extractStoreFlag(args []string, def string) (string, []string) {
+	for i, a := range args {
+		switch {
+		case a == "--store" && i+1 < len(args):
+			rest := append(append([]string{}, args[:i]...), args[i+2:]...)
+			return args[i+1], rest
+		case len(a) > len("--store=") && a[:len("--store=")] == "--store=":
+			rest := append(append([]string{}, args[:i]...), args[i+1:]...)
+			return a[len("--store="):], rest
 		}
 	}
-	return r.save()
+	return def, args
 }
 
-func (r *JsonTaskRepository) Delete(id int) error {
-	for i, t := range r.tasks {
-		if t.ID == id {
-			r.tasks = append(r.tasks[:i], r.tasks[i+1:]...)
+// extractServerFlag pulls the first "--server=<url>" (or "--server <url>")
+// out of args, wherever it appears, returning the server URL (empty if absent, meaning "use the
+// local store") and the remaining args.
+func extractServerFlag(args []string) (string, []string) {
+	for i, a := range args {
+		switch {
+		case a == "--server" && i+1 < len(args):
+			rest := append(append([]string{}, args[:i]...), args[i+2:]...)
+			return args[i+1], rest
+		case len(a) > len("--server=") && a[:len("--server=")] == "--server=":
+			rest := append(append([]string{}, args[:i]...), args[i+1:]...)
+			return a[len("--server="):], rest
 		}
 	}
-
-	return r.save()
+	return "", args
 }
 
-func (r *JsonTaskRepository) MarkInProgress(id int) error {
+// extractLogFormatFlag pulls the first "--log-format=<format>" (or
+// "--log-format <format>") out of args, wherever it appears, returning the resolved format
+// ("console" if absent) and the remaining args.
+func extractLogFormatFlag(args []string) (string, []string) {
+	for i, a := range args {
+		switch {
+		case a == "--log-format" && i+1 < len(args):
+			rest := append(append([]string{}, args[:i]...), args[i+2:]...)
+			return args[i+1], rest
+		case len(a) > len("--log-format=") && a[:len("--log-format=")] == "--log-format=":
+			rest := append(append([]string{}, args[:i]...), args[i+1:]...)
+			return a[len("--log-format="):], rest
+		}
+	}
+	return "console", args
+}
 
-	for i, t := range r.tasks {
-		if t.ID == id {
-			r.tasks[i].Status = "in-progress"
-			r.tasks[i].UpdatedAt = time.Now().UTC()
+// extractIDFormatFlag pulls the first "--id-format=<format>" (or
+// "--id-format <format>") out of args, wherever it appears, returning the resolved
+// storage.IDFormat (storage.IDFormatInt if absent) and the remaining args.
+func extractIDFormatFlag(args []string) (storage.IDFormat, []string) {
+	for i, a := range args {
+		switch {
+		case a == "--id-format" && i+1 < len(args):
+			rest := append(append([]string{}, args[:i]...), args[i+2:]...)
+			return storage.IDFormat(args[i+1]), rest
+		case len(a) > len("--id-format=") && a[:len("--id-format=")] == "--id-format=":
+			rest := append(append([]string{}, args[:i]...), args[i+1:]...)
+			return storage.IDFormat(a[len("--id-format="):]), rest
 		}
 	}
+	return storage.IDFormatInt, args
+}
 
-	return r.save()
+func printUsage() {
+	fmt.Println("Usage: task-tracker [--store json|sqlite|bolt] [--server <url>] [--log-format console|json] [--id-format int|uuid] <command> [args]")
+	fmt.Println("Commands:")
+	fmt.Println(`  add <description> [--priority low|med|high] [--due 2025-01-01] [--tag work]`)
+	fmt.Println("  update <id> <description>")
+	fmt.Println("  delete <id>")
+	fmt.Println("  mark-in-progress <id>")
+	fmt.Println("  mark-done <id>")
+	fmt.Println(`  list [--status done] [--due-before 2025-01-01] [--tag work] [--sort due,-priority] [--format table|json|csv]`)
+	fmt.Println("  migrate --from <backend> --to <backend>")
+	fmt.Println("  sync")
+	fmt.Println("  serve --addr :8080")
 }
 
-func (r *JsonTaskRepository) MarkDone(id int) error {
+// stringSliceFlag collects repeated uses of the same flag (e.g. several
+// --tag flags) into a slice.
+type stringSliceFlag []string
 
-	for i, t := range r.tasks {
-		if t.ID == id {
-			r.tasks[i].Status = "done"
-			r.tasks[i].UpdatedAt = time.Now().UTC()
-		}
-	}
+func (s *stringSliceFlag) String() string {
+	return strings.Join(*s, ",")
+}
 
-	return r.save()
+func (s *stringSliceFlag) Set(value string) error {
+	*s = append(*s, value)
+	return nil
 }
 
-func main() {
-	if len(os.Args) < 2 {
-		printUsage()
-		os.Exit(1)
+func add(args []string, repo storage.Repository, d *sync.Dispatcher, logger zerolog.Logger) error {
+	fs := flag.NewFlagSet("add", flag.ContinueOnError)
+	priority := fs.String("priority", "", "priority: low, med, or high")
+	due := fs.String("due", "", "due date (YYYY-MM-DD)")
+	var tags stringSliceFlag
+	fs.Var(&tags, "tag", "tag to attach to the task (repeatable)")
+	// Parse repeatedly so flags are accepted after the description too.
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return err
+		}
+		if fs.NArg() == 0 {
+			break
+		}
+		positional = append(positional, fs.Arg(0))
+		args = fs.Args()[1:]
 	}
-
-	repo, err := newJsonTaskRepository("tasks.json")
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error initializing repository: %v\n", err)
-		os.Exit(1)
+	if len(positional) != 1 {
+		return errors.New("help: task-tracker add \"task description\" [--priority low|med|high] [--due 2025-01-01] [--tag work]")
 	}
 
-	command := os.Args[1]
-	args := os.Args[2:]
+	if *priority != "" && !slices.Contains([]string{"low", "med", "high"}, *priority) {
+		return fmt.Errorf("add: invalid --priority %q (want low, med, or high)", *priority)
+	}
 
-	switch command {
-	case "add":
-		err = add(args, repo)
-	case "update":
-		err = update(args, repo)
-	case "delete":
-		err = delete(args, repo)
-	case "mark-in-progress":
-		err = markInProgress(args, repo)
-	case "mark-done":
-		err = markDone(args, repo)
-	case "list":
-		err = list(args, repo)
-	default:
-		printUsage()
-		os.Exit(1)
+	input := storage.TaskInput{Priority: *priority, Tags: tags}
+	if *due != "" {
+		dueAt, err := time.Parse(dateLayout, *due)
+		if err != nil {
+			return fmt.Errorf("invalid --due %q: %w", *due, err)
+		}
+		input.DueAt = &dueAt
 	}
 
+	task, err := repo.Add(positional[0], input)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		os.Exit(1)
+		return wrapRepoErr(err)
 	}
+	fmt.Printf("task added successfully (ID: %d)\n", task.ID)
+	logger.Debug().Int("task_id", task.ID).Str("status", task.Status).Msg("task added")
+	dispatch(d, storage.Update{Op: storage.OpAdd, Task: task, At: task.CreatedAt}, logger)
+	return nil
 }
 
-func printUsage() {
-	fmt.Println("Usage: task-tracker <command> [args]")
-	fmt.Println("Commands:")
-	fmt.Println("  add <description>")
-	fmt.Println("  update <id> <description>")
-	fmt.Println("  delete <id>")
-	fmt.Println("  mark-in-progress <id>")
-	fmt.Println("  mark-done <id>")
-	fmt.Println("  list [status]")
-}
-
-func add(args []string, repo TaskRepository) error {
-	if len(args) != 1 {
-		return errors.New("help: task-tracker add \"task description\"")
+// resolveID turns a CLI id argument into the task's integer ID, accepting
+// either form a task can be identified by: the plain integer ID, or (when
+// the store was populated with --id-format=uuid) its UUID.
+func resolveID(repo storage.Repository, raw string) (int, error) {
+	if id, err := strconv.Atoi(raw); err == nil {
+		return id, nil
 	}
 
-	task, err := repo.Add(args[0])
+	tasks, err := repo.List(storage.Query{})
 	if err != nil {
-		return errors.New(err.Error())
+		return 0, wrapRepoErr(err)
 	}
-	fmt.Printf("task added successfully (ID: %d)\n", task.ID)
-	return nil
+	for _, t := range tasks {
+		if t.UUID == raw {
+			return t.ID, nil
+		}
+	}
+
+	return 0, fmt.Errorf("%w: %q", ErrInvalidID, raw)
 }
 
-func update(args []string, repo TaskRepository) error {
+func update(args []string, repo storage.Repository, d *sync.Dispatcher, logger zerolog.Logger) error {
 	if len(args) != 2 {
 		return errors.New("help: task-tracker update 1 \"new task description\"")
 	}
-	id, err := strconv.Atoi(args[0])
+	id, err := resolveID(repo, args[0])
 	if err != nil {
-		return errors.New("invalid id")
+		return err
+	}
+	if err := repo.Update(id, args[1]); err != nil {
+		return wrapRepoErr(err)
 	}
-	return repo.Update(id, args[1])
+	logger.Debug().Int("task_id", id).Msg("task updated")
+	dispatch(d, storage.Update{Op: storage.OpUpdate, TaskID: id, Description: args[1], At: time.Now().UTC()}, logger)
+	return nil
 }
 
-func delete(args []string, repo TaskRepository) error {
+func delete(args []string, repo storage.Repository, d *sync.Dispatcher, logger zerolog.Logger) error {
 	if len(args) != 1 {
 		return errors.New("help: task-tracker delete 1")
 	}
-	id, err := strconv.Atoi(args[0])
+	id, err := resolveID(repo, args[0])
 	if err != nil {
-		return errors.New("invalid id")
+		return err
+	}
+	if err := repo.Delete(id); err != nil {
+		return wrapRepoErr(err)
 	}
-	return repo.Delete(id)
+	logger.Debug().Int("task_id", id).Msg("task deleted")
+	dispatch(d, storage.Update{Op: storage.OpDelete, TaskID: id, At: time.Now().UTC()}, logger)
+	return nil
 }
 
-func markInProgress(args []string, repo TaskRepository) error {
+func markInProgress(args []string, repo storage.Repository, d *sync.Dispatcher, logger zerolog.Logger) error {
 	if len(args) != 1 {
 		return errors.New("help: task-tracker mark-in-progress 1")
 	}
-	id, err := strconv.Atoi(args[0])
+	id, err := resolveID(repo, args[0])
 	if err != nil {
-		return errors.New("invalid id")
+		return err
 	}
-	return repo.MarkInProgress(id)
+	if err := repo.MarkInProgress(id); err != nil {
+		return wrapRepoErr(err)
+	}
+	logger.Debug().Int("task_id", id).Str("status", "in-progress").Msg("task status changed")
+	dispatch(d, storage.Update{Op: storage.OpMarkInProgress, TaskID: id, At: time.Now().UTC()}, logger)
+	return nil
 }
 
-func markDone(args []string, repo TaskRepository) error {
+func markDone(args []string, repo storage.Repository, d *sync.Dispatcher, logger zerolog.Logger) error {
 	if len(args) != 1 {
 		return errors.New("help: task-tracker mark-done 1")
 	}
-	id, err := strconv.Atoi(args[0])
+	id, err := resolveID(repo, args[0])
 	if err != nil {
-		return errors.New("invalid id")
+		return err
+	}
+	if err := repo.MarkDone(id); err != nil {
+		return wrapRepoErr(err)
 	}
-	return repo.MarkDone(id)
+	logger.Debug().Int("task_id", id).Str("status", "done").Msg("task status changed")
+	dispatch(d, storage.Update{Op: storage.OpMarkDone, TaskID: id, At: time.Now().UTC()}, logger)
+	return nil
 }
 
-func list(args []string, repo TaskRepository) error {
-	switch len(args) {
-	case 0:
-		tasks, err := repo.List("")
+// list runs a query against the repository and prints the result in one of
+// three formats. Usage: task-tracker list [--status done] [--due-before
+// 2025-01-01] [--tag work] [--sort due,-priority] [--format table|json|csv]
+func list(args []string, repo storage.Repository) error {
+	fs := flag.NewFlagSet("list", flag.ContinueOnError)
+	status := fs.String("status", "", "filter by status: todo, in-progress, or done")
+	dueBefore := fs.String("due-before", "", "filter to tasks due before this date (YYYY-MM-DD)")
+	tag := fs.String("tag", "", "filter by tag")
+	sortBy := fs.String("sort", "", `comma-separated sort fields, e.g. "due,-priority"`)
+	format := fs.String("format", "table", "output format: table, json, or csv")
+	// Parse repeatedly so flags are accepted after a positional status too.
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return err
+		}
+		if fs.NArg() == 0 {
+			break
+		}
+		positional = append(positional, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+
+	if len(positional) > 1 {
+		return errors.New("help: task-tracker list [status] [flags]")
+	}
+
+	statusFilter := *status
+	if statusFilter == "" && len(positional) == 1 {
+		statusFilter = positional[0]
+	}
+	if statusFilter != "" && !slices.Contains([]string{"todo", "in-progress", "done"}, statusFilter) {
+		return storage.ErrInvalidStatus
+	}
+
+	query := storage.Query{Status: statusFilter, Tag: *tag}
+	if *sortBy != "" {
+		query.Sort = strings.Split(*sortBy, ",")
+	}
+	if *dueBefore != "" {
+		t, err := time.Parse(dateLayout, *dueBefore)
 		if err != nil {
-			return errors.New(err.Error())
+			return fmt.Errorf("invalid --due-before %q: %w", *dueBefore, err)
 		}
-		for _, task := range tasks {
-			fmt.Println(task.ID, task.Description, task.Status)
+		query.DueBefore = &t
+	}
+
+	tasks, err := repo.List(query)
+	if err != nil {
+		return wrapRepoErr(err)
+	}
+
+	switch *format {
+	case "table":
+		return printTable(tasks)
+	case "json":
+		return printJSON(tasks)
+	case "csv":
+		return printCSV(tasks)
+	default:
+		return fmt.Errorf("list: unknown --format %q", *format)
+	}
+}
+
+func printTable(tasks []storage.Task) error {
+	w := tabwriter.NewWriter(os.Stdout, 0, 2, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tSTATUS\tPRIORITY\tDUE\tTAGS\tDESCRIPTION")
+	for _, t := range tasks {
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\t%s\n",
+			t.ID, t.Status, orDash(t.Priority), formatDue(t.DueAt), strings.Join(t.Tags, ","), t.Description)
+	}
+	return w.Flush()
+}
+
+func printJSON(tasks []storage.Task) error {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(tasks)
+}
+
+func printCSV(tasks []storage.Task) error {
+	w := csv.NewWriter(os.Stdout)
+	if err := w.Write([]string{"id", "status", "priority", "due", "tags", "description"}); err != nil {
+		return err
+	}
+	for _, t := range tasks {
+		record := []string{
+			strconv.Itoa(t.ID), t.Status, t.Priority, formatDue(t.DueAt), strings.Join(t.Tags, ";"), t.Description,
 		}
-		return nil
-	case 1:
-		if !slices.Contains([]string{"todo", "in-progress", "done"}, args[0]) {
-			return errors.New("invalid status")
+		if err := w.Write(record); err != nil {
+			return err
 		}
-		tasks, err := repo.List(args[0])
-		if err != nil {
-			return errors.New(err.Error())
+	}
+	w.Flush()
+	return w.Error()
+}
+
+func formatDue(t *time.Time) string {
+	if t == nil {
+		return "-"
+	}
+	return t.Format(dateLayout)
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+// serve starts the HTTP API, sharing the same repository the CLI commands
+// use so a team can point several machines at one backend. Usage:
+// task-tracker serve --addr :8080
+func serve(args []string, backend string, idFormat storage.IDFormat) error {
+	addr := ":8080"
+	for i := 0; i < len(args); i++ {
+		if args[i] == "--addr" && i+1 < len(args) {
+			i++
+			addr = args[i]
+			continue
 		}
-		for _, task := range tasks {
-			fmt.Println(task.ID, task.Description, task.Status)
+		return fmt.Errorf("serve: unknown flag %q", args[i])
+	}
+
+	repo, err := storage.Open(backend, storePath(backend), idFormat)
+	if err != nil {
+		return fmt.Errorf("open repository: %w", err)
+	}
+	defer repo.Close()
+
+	fmt.Printf("task-tracker serving on %s\n", addr)
+	return server.ListenAndServe(addr, repo)
+}
+
+// runSync pulls pending updates from the configured mailbox and applies
+// them to the local JSON store. Sync only ever operates on the JSON
+// backend, since that's the store ApplyUpdate knows how to merge into.
+func runSync() error {
+	path, err := sync.ConfigPath()
+	if err != nil {
+		return err
+	}
+	cfg, err := sync.LoadConfig(path)
+	if err != nil {
+		return fmt.Errorf("sync is not configured: %w", err)
+	}
+
+	repo, err := storage.NewJSONRepository(storePath(storage.BackendJSON), storage.IDFormatInt)
+	if err != nil {
+		return fmt.Errorf("open local store: %w", err)
+	}
+	defer repo.Close()
+
+	remote := sync.NewIMAPRemote(cfg)
+	applied, err := sync.Sync(repo, remote)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("applied %d update(s) from remote\n", applied)
+	return nil
+}
+
+// migrate copies every task from one backend to another, preserving IDs and
+// timestamps. Usage: task-tracker migrate --from json --to sqlite
+// [--from-path tasks.json] [--to-path tasks.db]
+func migrate(args []string) error {
+	var from, to, fromPath, toPath string
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--from", "--to", "--from-path", "--to-path":
+			if i+1 >= len(args) {
+				return fmt.Errorf("migrate: flag %q requires a value", args[i])
+			}
+		default:
+			return fmt.Errorf("migrate: unknown flag %q", args[i])
 		}
-		return nil
+
+		switch args[i] {
+		case "--from":
+			i++
+			from = args[i]
+		case "--to":
+			i++
+			to = args[i]
+		case "--from-path":
+			i++
+			fromPath = args[i]
+		case "--to-path":
+			i++
+			toPath = args[i]
+		}
+	}
+	if from == "" || to == "" {
+		return errors.New("help: task-tracker migrate --from <backend> --to <backend>")
 	}
-	if len(args) > 1 {
-		return errors.New("help: task-tracker list\ntask-cli list done\ntask-tracker list todo\ntask-tracker list in-progress")
+	if fromPath == "" {
+		fromPath = storePath(from)
 	}
+	if toPath == "" {
+		toPath = storePath(to)
+	}
+
+	src, err := storage.Open(from, fromPath, storage.IDFormatInt)
+	if err != nil {
+		return fmt.Errorf("open source backend %q: %w", from, err)
+	}
+	defer src.Close()
+
+	dst, err := storage.Open(to, toPath, storage.IDFormatInt)
+	if err != nil {
+		return fmt.Errorf("open destination backend %q: %w", to, err)
+	}
+	defer dst.Close()
+
+	importer, ok := dst.(storage.Importer)
+	if !ok {
+		return fmt.Errorf("destination backend %q does not support migration", to)
+	}
+
+	tasks, err := src.List(storage.Query{})
+	if err != nil {
+		return fmt.Errorf("read source tasks: %w", err)
+	}
+	if err := importer.Import(tasks); err != nil {
+		return fmt.Errorf("write destination tasks: %w", err)
+	}
+
+	fmt.Printf("migrated %d task(s) from %s to %s\n", len(tasks), from, to)
 	return nil
 }
@@ -0,0 +1,235 @@
+// Package server exposes a storage.Repository over HTTP so a team can share
+// one task-tracker backend instead of each running against a local file.
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/EssaAlshammri/task-tracker/storage"
+)
+
+// Server adapts a storage.Repository to the REST API registered in
+// Routes: GET/POST /tasks, PATCH/DELETE /tasks/{id}, and the two
+// status-transition endpoints.
+type Server struct {
+	repo storage.Repository
+}
+
+// New builds a Server backed by repo.
+func New(repo storage.Repository) *Server {
+	return &Server{repo: repo}
+}
+
+// ListenAndServe starts the HTTP API on addr, serving repo until the
+// process is killed or the server errors.
+func ListenAndServe(addr string, repo storage.Repository) error {
+	return http.ListenAndServe(addr, New(repo).Routes())
+}
+
+// Routes returns the HTTP handler for the API, using Go 1.22's
+// method-and-path mux patterns.
+func (s *Server) Routes() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /tasks", s.handleList)
+	mux.HandleFunc("POST /tasks", s.handleCreate)
+	mux.HandleFunc("PATCH /tasks/{id}", s.handleUpdate)
+	mux.HandleFunc("DELETE /tasks/{id}", s.handleDelete)
+	mux.HandleFunc("POST /tasks/{id}/in-progress", s.handleMarkInProgress)
+	mux.HandleFunc("POST /tasks/{id}/done", s.handleMarkDone)
+	return mux
+}
+
+func (s *Server) handleList(w http.ResponseWriter, r *http.Request) {
+	q := storage.Query{
+		Status: r.URL.Query().Get("status"),
+		Tag:    r.URL.Query().Get("tag"),
+	}
+	if sortBy := r.URL.Query().Get("sort"); sortBy != "" {
+		q.Sort = strings.Split(sortBy, ",")
+	}
+	if dueBefore := r.URL.Query().Get("due_before"); dueBefore != "" {
+		t, err := time.Parse("2006-01-02", dueBefore)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("invalid due_before: %w", err))
+			return
+		}
+		q.DueBefore = &t
+	}
+
+	tasks, err := s.repo.List(q)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, tasks)
+}
+
+type createRequest struct {
+	Description string     `json:"description"`
+	Priority    string     `json:"priority,omitempty"`
+	Tags        []string   `json:"tags,omitempty"`
+	DueAt       *time.Time `json:"dueAt,omitempty"`
+}
+
+func (s *Server) handleCreate(w http.ResponseWriter, r *http.Request) {
+	var req createRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if req.Priority != "" && !slices.Contains([]string{"low", "med", "high"}, req.Priority) {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid priority %q (want low, med, or high)", req.Priority))
+		return
+	}
+
+	task, err := s.repo.Add(req.Description, storage.TaskInput{Priority: req.Priority, Tags: req.Tags, DueAt: req.DueAt})
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, task)
+}
+
+type updateRequest struct {
+	Description string `json:"description"`
+}
+
+func (s *Server) handleUpdate(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	var req updateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := s.repo.Update(id, req.Description); err != nil {
+		writeStorageError(w, err)
+		return
+	}
+
+	task, err := findTask(s.repo, id)
+	if err != nil {
+		writeStorageError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, task)
+}
+
+func (s *Server) handleDelete(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := s.repo.Delete(id); err != nil {
+		writeStorageError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) handleMarkInProgress(w http.ResponseWriter, r *http.Request) {
+	s.transition(w, r, "in-progress", s.repo.MarkInProgress)
+}
+
+func (s *Server) handleMarkDone(w http.ResponseWriter, r *http.Request) {
+	s.transition(w, r, "done", s.repo.MarkDone)
+}
+
+// transition runs a mark-* mutation after checking that moving the task to
+// toStatus is legal from its current status, returning 409 if not.
+func (s *Server) transition(w http.ResponseWriter, r *http.Request, toStatus string, mutate func(int) error) {
+	id, err := pathID(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	task, err := findTask(s.repo, id)
+	if err != nil {
+		writeStorageError(w, err)
+		return
+	}
+	if !validTransition(task.Status, toStatus) {
+		writeError(w, http.StatusConflict, errInvalidTransition(task.Status, toStatus))
+		return
+	}
+
+	if err := mutate(id); err != nil {
+		writeStorageError(w, err)
+		return
+	}
+
+	updated, err := findTask(s.repo, id)
+	if err != nil {
+		writeStorageError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, updated)
+}
+
+// validTransition treats "done" as terminal: a task can move back and
+// forth between todo and in-progress, and either can move to done, but a
+// done task can't be reopened through these endpoints.
+func validTransition(from, to string) bool {
+	if from == "done" {
+		return to == "done"
+	}
+	return true
+}
+
+func errInvalidTransition(from, to string) error {
+	return fmt.Errorf("cannot move task from %q to %q", from, to)
+}
+
+func findTask(repo storage.Repository, id int) (*storage.Task, error) {
+	tasks, err := repo.List(storage.Query{})
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range tasks {
+		if t.ID == id {
+			return &t, nil
+		}
+	}
+	return nil, storage.ErrTaskNotFound
+}
+
+func pathID(r *http.Request) (int, error) {
+	return strconv.Atoi(r.PathValue("id"))
+}
+
+func writeStorageError(w http.ResponseWriter, err error) {
+	if errors.Is(err, storage.ErrTaskNotFound) {
+		writeError(w, http.StatusNotFound, err)
+		return
+	}
+	writeError(w, http.StatusInternalServerError, err)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, errorResponse{Error: err.Error()})
+}
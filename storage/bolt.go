@@ -0,0 +1,251 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"go.etcd.io/bbolt"
+)
+
+var (
+	tasksBucket  = []byte("tasks")
+	statusBucket = []byte("status_index")
+	metaBucket   = []byte("meta")
+	nextIDKey    = []byte("next_id")
+)
+
+// BoltRepository stores tasks in an embedded BoltDB file, keyed by task ID,
+// with a status_index bucket so List(status) can seek straight to the
+// matching keys instead of scanning every task.
+type BoltRepository struct {
+	db       *bbolt.DB
+	idFormat IDFormat
+}
+
+// NewBoltRepository opens (creating if necessary) the BoltDB file at
+// filename and ensures its buckets are in place.
+func NewBoltRepository(filename string, idFormat IDFormat) (*BoltRepository, error) {
+	db, err := bbolt.Open(filename, 0600, &bbolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("open bolt database: %w", err)
+	}
+
+	err = db.Update(func(tx *bbolt.Tx) error {
+		for _, name := range [][]byte{tasksBucket, statusBucket, metaBucket} {
+			if _, err := tx.CreateBucketIfNotExists(name); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("create buckets: %w", err)
+	}
+
+	return &BoltRepository{db: db, idFormat: idFormat}, nil
+}
+
+func idKey(id int) []byte {
+	key := make([]byte, 8)
+	binary.BigEndian.PutUint64(key, uint64(id))
+	return key
+}
+
+func statusKey(status string, id int) []byte {
+	key := append([]byte(status), 0)
+	return append(key, idKey(id)...)
+}
+
+func (r *BoltRepository) nextID(tx *bbolt.Tx) (int, error) {
+	meta := tx.Bucket(metaBucket)
+	var id uint64 = 1
+	if raw := meta.Get(nextIDKey); raw != nil {
+		id = binary.BigEndian.Uint64(raw) + 1
+	}
+	next := make([]byte, 8)
+	binary.BigEndian.PutUint64(next, id)
+	if err := meta.Put(nextIDKey, next); err != nil {
+		return 0, err
+	}
+	return int(id), nil
+}
+
+func putTask(tx *bbolt.Tx, task Task, prevStatus string) error {
+	data, err := json.Marshal(task)
+	if err != nil {
+		return err
+	}
+	if err := tx.Bucket(tasksBucket).Put(idKey(task.ID), data); err != nil {
+		return err
+	}
+	index := tx.Bucket(statusBucket)
+	if prevStatus != "" && prevStatus != task.Status {
+		if err := index.Delete(statusKey(prevStatus, task.ID)); err != nil {
+			return err
+		}
+	}
+	return index.Put(statusKey(task.Status, task.ID), nil)
+}
+
+func getTask(tx *bbolt.Tx, id int) (*Task, error) {
+	raw := tx.Bucket(tasksBucket).Get(idKey(id))
+	if raw == nil {
+		return nil, ErrTaskNotFound
+	}
+	var t Task
+	if err := json.Unmarshal(raw, &t); err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// List uses the status_index bucket to narrow down to matching keys when
+// q.Status is set, then applies any remaining filters and the sort in
+// memory.
+func (r *BoltRepository) List(q Query) ([]Task, error) {
+	var tasks []Task
+
+	err := r.db.View(func(tx *bbolt.Tx) error {
+		if q.Status == "" {
+			return tx.Bucket(tasksBucket).ForEach(func(_, v []byte) error {
+				var t Task
+				if err := json.Unmarshal(v, &t); err != nil {
+					return err
+				}
+				tasks = append(tasks, t)
+				return nil
+			})
+		}
+
+		prefix := append([]byte(q.Status), 0)
+		c := tx.Bucket(statusBucket).Cursor()
+		for k, _ := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, _ = c.Next() {
+			id := int(binary.BigEndian.Uint64(k[len(prefix):]))
+			t, err := getTask(tx, id)
+			if err != nil {
+				return err
+			}
+			tasks = append(tasks, *t)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	remaining := q
+	remaining.Status = ""
+	return applyQuery(tasks, remaining), nil
+}
+
+func (r *BoltRepository) Add(description string, input TaskInput) (*Task, error) {
+	var task Task
+
+	err := r.db.Update(func(tx *bbolt.Tx) error {
+		id, err := r.nextID(tx)
+		if err != nil {
+			return err
+		}
+		now := time.Now().UTC()
+		task = Task{
+			ID:          id,
+			Description: description,
+			Status:      "todo",
+			Priority:    input.Priority,
+			Tags:        input.Tags,
+			DueAt:       input.DueAt,
+			CreatedAt:   now,
+			UpdatedAt:   now,
+		}
+		if r.idFormat == IDFormatUUID {
+			taskUUID, err := uuid.NewV7()
+			if err != nil {
+				return fmt.Errorf("generate uuid: %w", err)
+			}
+			task.UUID = taskUUID.String()
+		}
+		return putTask(tx, task, "")
+	})
+
+	return &task, err
+}
+
+func (r *BoltRepository) Update(id int, description string) error {
+	return r.db.Update(func(tx *bbolt.Tx) error {
+		task, err := getTask(tx, id)
+		if err != nil {
+			return err
+		}
+		task.Description = description
+		task.UpdatedAt = time.Now().UTC()
+		return putTask(tx, *task, task.Status)
+	})
+}
+
+func (r *BoltRepository) Delete(id int) error {
+	return r.db.Update(func(tx *bbolt.Tx) error {
+		task, err := getTask(tx, id)
+		if err != nil {
+			return err
+		}
+		if err := tx.Bucket(tasksBucket).Delete(idKey(id)); err != nil {
+			return err
+		}
+		return tx.Bucket(statusBucket).Delete(statusKey(task.Status, id))
+	})
+}
+
+func (r *BoltRepository) setStatus(id int, status string) error {
+	return r.db.Update(func(tx *bbolt.Tx) error {
+		task, err := getTask(tx, id)
+		if err != nil {
+			return err
+		}
+		prevStatus := task.Status
+		task.Status = status
+		task.UpdatedAt = time.Now().UTC()
+		return putTask(tx, *task, prevStatus)
+	})
+}
+
+func (r *BoltRepository) MarkInProgress(id int) error {
+	return r.setStatus(id, "in-progress")
+}
+
+func (r *BoltRepository) MarkDone(id int) error {
+	return r.setStatus(id, "done")
+}
+
+// Import writes tasks with their existing IDs and timestamps, for use by
+// `task-tracker migrate`.
+func (r *BoltRepository) Import(tasks []Task) error {
+	return r.db.Update(func(tx *bbolt.Tx) error {
+		for _, t := range tasks {
+			if err := putTask(tx, t, ""); err != nil {
+				return fmt.Errorf("import task %d: %w", t.ID, err)
+			}
+		}
+		if len(tasks) == 0 {
+			return nil
+		}
+		maxID := tasks[0].ID
+		for _, t := range tasks {
+			if t.ID > maxID {
+				maxID = t.ID
+			}
+		}
+		next := make([]byte, 8)
+		binary.BigEndian.PutUint64(next, uint64(maxID))
+		return tx.Bucket(metaBucket).Put(nextIDKey, next)
+	})
+}
+
+// Close releases the underlying database file.
+func (r *BoltRepository) Close() error {
+	return r.db.Close()
+}
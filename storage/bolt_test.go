@@ -0,0 +1,29 @@
+package storage
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+func TestBoltRepositoryNotFoundErrors(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tasks.bolt")
+	repo, err := NewBoltRepository(path, IDFormatInt)
+	if err != nil {
+		t.Fatalf("open repository: %v", err)
+	}
+	defer repo.Close()
+
+	if err := repo.Update(99, "x"); !errors.Is(err, ErrTaskNotFound) {
+		t.Errorf("Update: got %v, want ErrTaskNotFound", err)
+	}
+	if err := repo.Delete(99); !errors.Is(err, ErrTaskNotFound) {
+		t.Errorf("Delete: got %v, want ErrTaskNotFound", err)
+	}
+	if err := repo.MarkInProgress(99); !errors.Is(err, ErrTaskNotFound) {
+		t.Errorf("MarkInProgress: got %v, want ErrTaskNotFound", err)
+	}
+	if err := repo.MarkDone(99); !errors.Is(err, ErrTaskNotFound) {
+		t.Errorf("MarkDone: got %v, want ErrTaskNotFound", err)
+	}
+}
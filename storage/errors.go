@@ -0,0 +1,11 @@
+package storage
+
+import "errors"
+
+// ErrTaskNotFound is returned by drivers when an operation references a task
+// ID that doesn't exist.
+var ErrTaskNotFound = errors.New("task not found")
+
+// ErrInvalidStatus is returned when a status string isn't one of
+// "todo", "in-progress" or "done".
+var ErrInvalidStatus = errors.New("invalid status")
@@ -0,0 +1,212 @@
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// JSONRepository stores tasks as a JSON document on disk. The document
+// tracks a next_id counter alongside the task list so IDs are never
+// reused after a delete, even across process restarts.
+type JSONRepository struct {
+	filename string
+	idFormat IDFormat
+	nextID   int
+	tasks    []Task
+	lock     *fileLock
+}
+
+// document is the on-disk shape of the JSON store.
+type document struct {
+	NextID int    `json:"next_id"`
+	Tasks  []Task `json:"tasks"`
+}
+
+// NewJSONRepository loads (or creates) the JSON document at filename. It
+// holds an exclusive advisory lock on a sibling ".lock" file until Close is
+// called, so two task-tracker invocations against the same document can't
+// race each other.
+func NewJSONRepository(filename string, idFormat IDFormat) (*JSONRepository, error) {
+	lock, err := lockFile(filename + ".lock")
+	if err != nil {
+		return nil, fmt.Errorf("lock %s: %w", filename, err)
+	}
+
+	repo := &JSONRepository{
+		filename: filename,
+		idFormat: idFormat,
+		nextID:   1,
+		lock:     lock,
+	}
+	if err := repo.load(); err != nil {
+		lock.Close()
+		return nil, err
+	}
+	return repo, nil
+}
+
+func (r *JSONRepository) load() error {
+	file, err := os.ReadFile(r.filename)
+	if os.IsNotExist(err) {
+		return r.save()
+	}
+	if err != nil {
+		return err
+	}
+
+	var doc document
+	if err := json.Unmarshal(file, &doc); err == nil && doc.NextID > 0 {
+		r.tasks = doc.Tasks
+		r.nextID = doc.NextID
+		return nil
+	}
+
+	// Fall back to the pre-next_id format: a bare array of tasks, with new
+	// IDs numbered from the highest existing ID + 1.
+	var tasks []Task
+	if err := json.Unmarshal(file, &tasks); err != nil {
+		return fmt.Errorf("parse %s: %w", r.filename, err)
+	}
+	r.tasks = tasks
+	r.nextID = 1
+	for _, t := range tasks {
+		if t.ID >= r.nextID {
+			r.nextID = t.ID + 1
+		}
+	}
+	return nil
+}
+
+// save writes the task set to a temporary file in the same directory,
+// fsyncs it, then renames it over filename. The rename is atomic, so a
+// reader never observes a partially-written document even if the process
+// is killed mid-write.
+func (r *JSONRepository) save() error {
+	tasks := r.tasks
+	if tasks == nil {
+		tasks = []Task{}
+	}
+	data, err := json.Marshal(document{NextID: r.nextID, Tasks: tasks})
+	if err != nil {
+		return fmt.Errorf("failed to marshal tasks: %w", err)
+	}
+
+	tmp := r.filename + ".tmp"
+	file, err := os.OpenFile(tmp, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return fmt.Errorf("create temp file: %w", err)
+	}
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		return fmt.Errorf("write temp file: %w", err)
+	}
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return fmt.Errorf("fsync temp file: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("close temp file: %w", err)
+	}
+
+	if err := os.Rename(tmp, r.filename); err != nil {
+		return fmt.Errorf("rename temp file: %w", err)
+	}
+
+	dir, err := os.Open(filepath.Dir(r.filename))
+	if err != nil {
+		return fmt.Errorf("open directory: %w", err)
+	}
+	defer dir.Close()
+	return dir.Sync()
+}
+
+func (r *JSONRepository) List(q Query) ([]Task, error) {
+	return applyQuery(r.tasks, q), nil
+}
+
+func (r *JSONRepository) Add(description string, input TaskInput) (*Task, error) {
+	task := Task{
+		ID:          r.nextID,
+		Description: description,
+		Status:      "todo",
+		Priority:    input.Priority,
+		Tags:        input.Tags,
+		DueAt:       input.DueAt,
+		CreatedAt:   time.Now().UTC(),
+		UpdatedAt:   time.Now().UTC(),
+	}
+	if r.idFormat == IDFormatUUID {
+		id, err := uuid.NewV7()
+		if err != nil {
+			return nil, fmt.Errorf("generate uuid: %w", err)
+		}
+		task.UUID = id.String()
+	}
+
+	r.nextID++
+	r.tasks = append(r.tasks, task)
+
+	return &task, r.save()
+}
+
+func (r *JSONRepository) Update(id int, description string) error {
+	for i, t := range r.tasks {
+		if t.ID == id {
+			r.tasks[i].Description = description
+			r.tasks[i].UpdatedAt = time.Now().UTC()
+			return r.save()
+		}
+	}
+	return ErrTaskNotFound
+}
+
+func (r *JSONRepository) Delete(id int) error {
+	for i, t := range r.tasks {
+		if t.ID == id {
+			r.tasks = append(r.tasks[:i], r.tasks[i+1:]...)
+			return r.save()
+		}
+	}
+	return ErrTaskNotFound
+}
+
+func (r *JSONRepository) MarkInProgress(id int) error {
+	return r.setStatus(id, "in-progress")
+}
+
+func (r *JSONRepository) MarkDone(id int) error {
+	return r.setStatus(id, "done")
+}
+
+func (r *JSONRepository) setStatus(id int, status string) error {
+	for i, t := range r.tasks {
+		if t.ID == id {
+			r.tasks[i].Status = status
+			r.tasks[i].UpdatedAt = time.Now().UTC()
+			return r.save()
+		}
+	}
+	return ErrTaskNotFound
+}
+
+// Import overwrites the in-memory task set with tasks (preserving their IDs
+// and timestamps) and persists it, for use by `task-tracker migrate`.
+func (r *JSONRepository) Import(tasks []Task) error {
+	r.tasks = append([]Task{}, tasks...)
+	for _, t := range tasks {
+		if t.ID >= r.nextID {
+			r.nextID = t.ID + 1
+		}
+	}
+	return r.save()
+}
+
+// Close releases the advisory lock taken out in NewJSONRepository.
+func (r *JSONRepository) Close() error {
+	return r.lock.Close()
+}
@@ -0,0 +1,64 @@
+package storage
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestJSONRepositoryLoadsLegacyArrayFormat(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "tasks.json")
+	legacy := `[
+		{"id": 1, "description": "first", "status": "todo", "createdAt": "2025-01-01T00:00:00Z", "updatedAt": "2025-01-01T00:00:00Z"},
+		{"id": 3, "description": "third", "status": "done", "createdAt": "2025-01-02T00:00:00Z", "updatedAt": "2025-01-02T00:00:00Z"}
+	]`
+	if err := os.WriteFile(path, []byte(legacy), 0644); err != nil {
+		t.Fatalf("write legacy file: %v", err)
+	}
+
+	repo, err := NewJSONRepository(path, IDFormatInt)
+	if err != nil {
+		t.Fatalf("open repository: %v", err)
+	}
+	defer repo.Close()
+
+	tasks, err := repo.List(Query{})
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	if len(tasks) != 2 {
+		t.Fatalf("got %d tasks, want 2", len(tasks))
+	}
+
+	task, err := repo.Add("fourth", TaskInput{})
+	if err != nil {
+		t.Fatalf("add: %v", err)
+	}
+	if task.ID != 4 {
+		t.Errorf("got next ID %d, want 4 (max existing ID + 1)", task.ID)
+	}
+}
+
+func TestJSONRepositoryNotFoundErrors(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tasks.json")
+	repo, err := NewJSONRepository(path, IDFormatInt)
+	if err != nil {
+		t.Fatalf("open repository: %v", err)
+	}
+	defer repo.Close()
+
+	if err := repo.Update(99, "x"); !errors.Is(err, ErrTaskNotFound) {
+		t.Errorf("Update: got %v, want ErrTaskNotFound", err)
+	}
+	if err := repo.Delete(99); !errors.Is(err, ErrTaskNotFound) {
+		t.Errorf("Delete: got %v, want ErrTaskNotFound", err)
+	}
+	if err := repo.MarkInProgress(99); !errors.Is(err, ErrTaskNotFound) {
+		t.Errorf("MarkInProgress: got %v, want ErrTaskNotFound", err)
+	}
+	if err := repo.MarkDone(99); !errors.Is(err, ErrTaskNotFound) {
+		t.Errorf("MarkDone: got %v, want ErrTaskNotFound", err)
+	}
+}
@@ -0,0 +1,53 @@
+//go:build windows
+
+package storage
+
+import (
+	"fmt"
+	"os"
+
+	"golang.org/x/sys/windows"
+)
+
+// fileLock is an advisory, exclusive lock on a sibling file, used to keep
+// two task-tracker invocations from racing on the same JSON document.
+type fileLock struct {
+	file *os.File
+}
+
+// lockFile creates (if necessary) and exclusively locks path, blocking
+// until any other holder releases it.
+func lockFile(path string) (*fileLock, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("open lock file: %w", err)
+	}
+
+	handle := windows.Handle(file.Fd())
+	overlapped := new(windows.Overlapped)
+	if err := windows.LockFileEx(
+		handle,
+		windows.LOCKFILE_EXCLUSIVE_LOCK,
+		0, 1, 0,
+		overlapped,
+	); err != nil {
+		file.Close()
+		return nil, fmt.Errorf("acquire lock: %w", err)
+	}
+
+	return &fileLock{file: file}, nil
+}
+
+// Close releases the lock and closes the underlying file handle.
+func (l *fileLock) Close() error {
+	if l == nil || l.file == nil {
+		return nil
+	}
+	handle := windows.Handle(l.file.Fd())
+	overlapped := new(windows.Overlapped)
+	if err := windows.UnlockFileEx(handle, 0, 1, 0, overlapped); err != nil {
+		l.file.Close()
+		return fmt.Errorf("release lock: %w", err)
+	}
+	return l.file.Close()
+}
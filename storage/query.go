@@ -0,0 +1,121 @@
+package storage
+
+import (
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Query selects and orders the tasks a call to List returns. The zero
+// Query matches every task and leaves them in the driver's natural order.
+type Query struct {
+	// Status restricts the results to tasks in this status, e.g. "done".
+	Status string
+	// Tag restricts the results to tasks carrying this tag.
+	Tag string
+	// DueBefore restricts the results to tasks due strictly before this
+	// time. Tasks with no due date never match.
+	DueBefore *time.Time
+	// Sort orders the results by one or more fields, applied left to
+	// right so each field breaks ties left by the one before it.
+	// Supported fields are "id", "due", "priority", "status", and
+	// "created"; prefix a field with "-" to sort it descending.
+	Sort []string
+}
+
+// priorityRank orders priorities low < med < high for sorting. An
+// unrecognized or empty priority sorts alongside "med".
+func priorityRank(p string) int {
+	switch p {
+	case "low":
+		return 0
+	case "high":
+		return 2
+	default:
+		return 1
+	}
+}
+
+func matchesQuery(t Task, q Query) bool {
+	if q.Status != "" && t.Status != q.Status {
+		return false
+	}
+	if q.Tag != "" && !slices.Contains(t.Tags, q.Tag) {
+		return false
+	}
+	if q.DueBefore != nil && (t.DueAt == nil || !t.DueAt.Before(*q.DueBefore)) {
+		return false
+	}
+	return true
+}
+
+// lessByField reports whether a sorts before b on the given field for the
+// given direction. A task with no due date is treated as due after every
+// task that has one, regardless of desc, since reversing the direction of
+// a comparison isn't the same as reversing which end nil belongs on.
+func lessByField(a, b Task, field string, desc bool) bool {
+	switch field {
+	case "id":
+		if desc {
+			return b.ID < a.ID
+		}
+		return a.ID < b.ID
+	case "status":
+		if desc {
+			return b.Status < a.Status
+		}
+		return a.Status < b.Status
+	case "priority":
+		ar, br := priorityRank(a.Priority), priorityRank(b.Priority)
+		if desc {
+			return br < ar
+		}
+		return ar < br
+	case "created":
+		if desc {
+			return b.CreatedAt.Before(a.CreatedAt)
+		}
+		return a.CreatedAt.Before(b.CreatedAt)
+	case "due":
+		if a.DueAt == nil || b.DueAt == nil {
+			return a.DueAt != nil
+		}
+		if desc {
+			return b.DueAt.Before(*a.DueAt)
+		}
+		return a.DueAt.Before(*b.DueAt)
+	default:
+		return false
+	}
+}
+
+// sortTasks orders tasks in place by fields, each optionally prefixed with
+// "-" for descending. Earlier fields take precedence; ties are broken by
+// the fields that follow.
+func sortTasks(tasks []Task, fields []string) {
+	for i := len(fields) - 1; i >= 0; i-- {
+		field := strings.TrimPrefix(fields[i], "-")
+		desc := strings.HasPrefix(fields[i], "-")
+		sort.SliceStable(tasks, func(a, b int) bool {
+			return lessByField(tasks[a], tasks[b], field, desc)
+		})
+	}
+}
+
+// applyQuery filters tasks down to the ones matching q and, if q.Sort is
+// set, orders them. It's the shared implementation the in-memory drivers
+// (JSON, Bolt) use directly, and that SQLite uses for the filters it
+// doesn't push down into SQL.
+func applyQuery(tasks []Task, q Query) []Task {
+	filtered := make([]Task, 0, len(tasks))
+	for _, t := range tasks {
+		if matchesQuery(t, q) {
+			filtered = append(filtered, t)
+		}
+	}
+	if len(q.Sort) > 0 {
+		sortTasks(filtered, q.Sort)
+	}
+	return filtered
+}
@@ -0,0 +1,99 @@
+package storage
+
+import (
+	"testing"
+	"time"
+)
+
+func mustDate(t *testing.T, s string) time.Time {
+	t.Helper()
+	d, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		t.Fatalf("parse date %q: %v", s, err)
+	}
+	return d
+}
+
+func TestApplyQueryFilters(t *testing.T) {
+	jan1 := mustDate(t, "2025-01-01")
+	jan10 := mustDate(t, "2025-01-10")
+
+	tasks := []Task{
+		{ID: 1, Status: "todo", Tags: []string{"work"}, DueAt: &jan1},
+		{ID: 2, Status: "done", Tags: []string{"home"}, DueAt: &jan10},
+		{ID: 3, Status: "todo", Tags: []string{"work", "urgent"}},
+	}
+
+	tests := []struct {
+		name string
+		q    Query
+		want []int
+	}{
+		{"no filter", Query{}, []int{1, 2, 3}},
+		{"status", Query{Status: "todo"}, []int{1, 3}},
+		{"tag", Query{Tag: "work"}, []int{1, 3}},
+		{"tag no match", Query{Tag: "missing"}, nil},
+		{"due before excludes no-due-date tasks", Query{DueBefore: &jan10}, []int{1}},
+		{"status and tag combine", Query{Status: "todo", Tag: "urgent"}, []int{3}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := applyQuery(tasks, tt.q)
+			assertIDs(t, got, tt.want)
+		})
+	}
+}
+
+func TestApplyQuerySort(t *testing.T) {
+	jan1 := mustDate(t, "2025-01-01")
+	jan10 := mustDate(t, "2025-01-10")
+
+	tasks := []Task{
+		{ID: 1, Priority: "low", DueAt: &jan10},
+		{ID: 2, Priority: "high", DueAt: &jan1},
+		{ID: 3, Priority: "high"}, // no due date, sorts last on "due"
+	}
+
+	tests := []struct {
+		name string
+		sort []string
+		want []int
+	}{
+		{"due ascending, no-due-date last", []string{"due"}, []int{2, 1, 3}},
+		{"due descending, no-due-date still last", []string{"-due"}, []int{1, 2, 3}},
+		{"priority ascending", []string{"priority"}, []int{1, 2, 3}},
+		{"priority descending then id", []string{"-priority", "id"}, []int{2, 3, 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := applyQuery(tasks, Query{Sort: tt.sort})
+			assertIDs(t, got, tt.want)
+		})
+	}
+}
+
+func assertIDs(t *testing.T, got []Task, want []int) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("got %d tasks, want %d: %v", len(got), len(want), got)
+	}
+	for i, task := range got {
+		if task.ID != want[i] {
+			t.Errorf("position %d: got ID %d, want %d", i, task.ID, want[i])
+		}
+	}
+}
+
+func TestPriorityRank(t *testing.T) {
+	if priorityRank("low") >= priorityRank("med") {
+		t.Error("low should rank below med")
+	}
+	if priorityRank("med") >= priorityRank("high") {
+		t.Error("med should rank below high")
+	}
+	if priorityRank("") != priorityRank("med") {
+		t.Error("unrecognized priority should rank alongside med")
+	}
+}
@@ -0,0 +1,239 @@
+package storage
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	_ "modernc.org/sqlite"
+)
+
+// SQLiteRepository stores tasks in a SQLite database, indexed by status and
+// created_at so List doesn't have to scan every row.
+type SQLiteRepository struct {
+	db       *sql.DB
+	idFormat IDFormat
+}
+
+// The AUTOINCREMENT keyword makes SQLite track the highest id ever used in
+// a separate sqlite_sequence table, so ids are never reused after a delete
+// even though the table itself may be empty.
+const sqliteSchema = `
+CREATE TABLE IF NOT EXISTS tasks (
+	id          INTEGER PRIMARY KEY AUTOINCREMENT,
+	uuid        TEXT,
+	description TEXT NOT NULL,
+	status      TEXT NOT NULL,
+	priority    TEXT,
+	tags        TEXT,
+	due_at      DATETIME,
+	created_at  DATETIME NOT NULL,
+	updated_at  DATETIME NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_tasks_status ON tasks(status);
+CREATE INDEX IF NOT EXISTS idx_tasks_created_at ON tasks(created_at);
+`
+
+// NewSQLiteRepository opens (creating if necessary) the SQLite database at
+// filename and ensures its schema is in place.
+func NewSQLiteRepository(filename string, idFormat IDFormat) (*SQLiteRepository, error) {
+	db, err := sql.Open("sqlite", filename)
+	if err != nil {
+		return nil, fmt.Errorf("open sqlite database: %w", err)
+	}
+	if _, err := db.Exec(sqliteSchema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("create schema: %w", err)
+	}
+	return &SQLiteRepository{db: db, idFormat: idFormat}, nil
+}
+
+// List runs the status filter in SQL, since it's indexed, then applies any
+// remaining filters and the sort in memory via the same logic the JSON
+// driver uses. Pushing the rest down into SQL is left for a future pass.
+func (r *SQLiteRepository) List(q Query) ([]Task, error) {
+	query := "SELECT id, uuid, description, status, priority, tags, due_at, created_at, updated_at FROM tasks"
+	args := []any{}
+	if q.Status != "" {
+		query += " WHERE status = ?"
+		args = append(args, q.Status)
+	}
+	query += " ORDER BY created_at"
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("list tasks: %w", err)
+	}
+	defer rows.Close()
+
+	var tasks []Task
+	for rows.Next() {
+		var t Task
+		var taskUUID, priority, tags sql.NullString
+		var dueAt sql.NullTime
+		if err := rows.Scan(&t.ID, &taskUUID, &t.Description, &t.Status, &priority, &tags, &dueAt, &t.CreatedAt, &t.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("scan task: %w", err)
+		}
+		t.UUID = taskUUID.String
+		t.Priority = priority.String
+		if tags.Valid && tags.String != "" {
+			if err := json.Unmarshal([]byte(tags.String), &t.Tags); err != nil {
+				return nil, fmt.Errorf("decode tags: %w", err)
+			}
+		}
+		if dueAt.Valid {
+			due := dueAt.Time
+			t.DueAt = &due
+		}
+		tasks = append(tasks, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	remaining := q
+	remaining.Status = ""
+	return applyQuery(tasks, remaining), nil
+}
+
+func (r *SQLiteRepository) Add(description string, input TaskInput) (*Task, error) {
+	now := time.Now().UTC()
+	task := Task{
+		Description: description,
+		Status:      "todo",
+		Priority:    input.Priority,
+		Tags:        input.Tags,
+		DueAt:       input.DueAt,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if r.idFormat == IDFormatUUID {
+		id, err := uuid.NewV7()
+		if err != nil {
+			return nil, fmt.Errorf("generate uuid: %w", err)
+		}
+		task.UUID = id.String()
+	}
+
+	result, err := r.db.Exec(
+		`INSERT INTO tasks (uuid, description, status, priority, tags, due_at, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		nullableString(task.UUID), task.Description, task.Status, nullableString(task.Priority), nullableTags(task.Tags), nullableTime(task.DueAt), task.CreatedAt, task.UpdatedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("insert task: %w", err)
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, fmt.Errorf("read inserted id: %w", err)
+	}
+	task.ID = int(id)
+
+	return &task, nil
+}
+
+func nullableString(s string) any {
+	if s == "" {
+		return nil
+	}
+	return s
+}
+
+// nullableTags JSON-encodes tags so a tag containing a comma round-trips
+// intact, unlike a naive comma-joined string.
+func nullableTags(tags []string) any {
+	if len(tags) == 0 {
+		return nil
+	}
+	data, err := json.Marshal(tags)
+	if err != nil {
+		return nil
+	}
+	return string(data)
+}
+
+func nullableTime(t *time.Time) any {
+	if t == nil {
+		return nil
+	}
+	return *t
+}
+
+func (r *SQLiteRepository) Update(id int, description string) error {
+	result, err := r.db.Exec(
+		`UPDATE tasks SET description = ?, updated_at = ? WHERE id = ?`,
+		description, time.Now().UTC(), id,
+	)
+	if err != nil {
+		return fmt.Errorf("update task %d: %w", id, err)
+	}
+	return rowsAffectedErr(result, id)
+}
+
+func (r *SQLiteRepository) Delete(id int) error {
+	result, err := r.db.Exec(`DELETE FROM tasks WHERE id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("delete task %d: %w", id, err)
+	}
+	return rowsAffectedErr(result, id)
+}
+
+func (r *SQLiteRepository) setStatus(id int, status string) error {
+	result, err := r.db.Exec(
+		`UPDATE tasks SET status = ?, updated_at = ? WHERE id = ?`,
+		status, time.Now().UTC(), id,
+	)
+	if err != nil {
+		return fmt.Errorf("update task %d status: %w", id, err)
+	}
+	return rowsAffectedErr(result, id)
+}
+
+// rowsAffectedErr returns ErrTaskNotFound when result reports that no row
+// matched id, so SQLite's typed-error contract matches the JSON and Bolt
+// drivers.
+func rowsAffectedErr(result sql.Result, id int) error {
+	n, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("check rows affected for task %d: %w", id, err)
+	}
+	if n == 0 {
+		return ErrTaskNotFound
+	}
+	return nil
+}
+
+func (r *SQLiteRepository) MarkInProgress(id int) error {
+	return r.setStatus(id, "in-progress")
+}
+
+func (r *SQLiteRepository) MarkDone(id int) error {
+	return r.setStatus(id, "done")
+}
+
+// Import inserts tasks with their existing IDs and timestamps, for use by
+// `task-tracker migrate`.
+func (r *SQLiteRepository) Import(tasks []Task) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("begin import: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, t := range tasks {
+		if _, err := tx.Exec(
+			`INSERT INTO tasks (id, uuid, description, status, priority, tags, due_at, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			t.ID, nullableString(t.UUID), t.Description, t.Status, nullableString(t.Priority), nullableTags(t.Tags), nullableTime(t.DueAt), t.CreatedAt, t.UpdatedAt,
+		); err != nil {
+			return fmt.Errorf("import task %d: %w", t.ID, err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// Close releases the underlying database handle.
+func (r *SQLiteRepository) Close() error {
+	return r.db.Close()
+}
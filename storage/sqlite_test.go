@@ -0,0 +1,55 @@
+package storage
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+func TestSQLiteRepositoryNotFoundErrors(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tasks.db")
+	repo, err := NewSQLiteRepository(path, IDFormatInt)
+	if err != nil {
+		t.Fatalf("open repository: %v", err)
+	}
+	defer repo.Close()
+
+	if err := repo.Update(99, "x"); !errors.Is(err, ErrTaskNotFound) {
+		t.Errorf("Update: got %v, want ErrTaskNotFound", err)
+	}
+	if err := repo.Delete(99); !errors.Is(err, ErrTaskNotFound) {
+		t.Errorf("Delete: got %v, want ErrTaskNotFound", err)
+	}
+	if err := repo.MarkInProgress(99); !errors.Is(err, ErrTaskNotFound) {
+		t.Errorf("MarkInProgress: got %v, want ErrTaskNotFound", err)
+	}
+	if err := repo.MarkDone(99); !errors.Is(err, ErrTaskNotFound) {
+		t.Errorf("MarkDone: got %v, want ErrTaskNotFound", err)
+	}
+}
+
+func TestSQLiteRepositoryTagsRoundTripCommas(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tasks.db")
+	repo, err := NewSQLiteRepository(path, IDFormatInt)
+	if err != nil {
+		t.Fatalf("open repository: %v", err)
+	}
+	defer repo.Close()
+
+	if _, err := repo.Add("x", TaskInput{Tags: []string{"a,b", "c"}}); err != nil {
+		t.Fatalf("add: %v", err)
+	}
+
+	tasks, err := repo.List(Query{})
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	if len(tasks) != 1 {
+		t.Fatalf("got %d tasks, want 1", len(tasks))
+	}
+	got := tasks[0].Tags
+	want := []string{"a,b", "c"}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("got tags %v, want %v", got, want)
+	}
+}
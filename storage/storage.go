@@ -0,0 +1,90 @@
+// Package storage defines the task persistence layer and the concrete
+// drivers (JSON file, SQLite, BoltDB) that implement it.
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrUnknownBackend is returned by Open when asked for a driver name it
+// doesn't recognize.
+var ErrUnknownBackend = errors.New("unknown storage backend")
+
+// Task is a single tracked item, independent of which backend stores it.
+type Task struct {
+	ID          int        `json:"id"`
+	UUID        string     `json:"uuid,omitempty"`
+	Description string     `json:"description"`
+	Status      string     `json:"status"`
+	Priority    string     `json:"priority,omitempty"`
+	Tags        []string   `json:"tags,omitempty"`
+	DueAt       *time.Time `json:"dueAt,omitempty"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	UpdatedAt   time.Time  `json:"updatedAt"`
+}
+
+// TaskInput carries the optional fields a new task can be created with,
+// beyond its description.
+type TaskInput struct {
+	Priority string
+	Tags     []string
+	DueAt    *time.Time
+}
+
+// IDFormat selects what Add populates alongside a task's integer ID.
+type IDFormat string
+
+const (
+	// IDFormatInt is the default: tasks only carry their integer ID.
+	IDFormatInt IDFormat = "int"
+	// IDFormatUUID additionally stamps each new task with a time-ordered
+	// UUID v7, for callers that want a global identifier that's stable
+	// across a migration or a sync merge.
+	IDFormatUUID IDFormat = "uuid"
+)
+
+// Repository is the storage-agnostic interface every driver implements.
+type Repository interface {
+	Add(description string, input TaskInput) (*Task, error)
+	Update(id int, description string) error
+	Delete(id int) error
+	MarkInProgress(id int) error
+	MarkDone(id int) error
+	List(q Query) ([]Task, error)
+	Close() error
+}
+
+// Importer is implemented by drivers that support writing tasks with their
+// IDs and timestamps already assigned, which the migrate command needs in
+// order to carry records from one backend to another without renumbering
+// them.
+type Importer interface {
+	Import(tasks []Task) error
+}
+
+// Backend names accepted by --store / TASKS_BACKEND.
+const (
+	BackendJSON   = "json"
+	BackendSQLite = "sqlite"
+	BackendBolt   = "bolt"
+)
+
+// Open returns the Repository for the named backend, rooted at path.
+// path is a file path for all three drivers: the JSON document, the
+// SQLite database file, or the BoltDB file. idFormat controls what new
+// tasks added through the returned Repository carry alongside their
+// integer ID.
+func Open(backend, path string, idFormat IDFormat) (Repository, error) {
+	switch backend {
+	case BackendJSON, "":
+		return NewJSONRepository(path, idFormat)
+	case BackendSQLite:
+		return NewSQLiteRepository(path, idFormat)
+	case BackendBolt:
+		return NewBoltRepository(path, idFormat)
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownBackend, backend)
+	}
+}
@@ -0,0 +1,83 @@
+package storage
+
+import (
+	"fmt"
+	"time"
+)
+
+// Op identifies the kind of mutation an Update carries.
+type Op string
+
+// The set of mutations that can be replicated between devices.
+const (
+	OpAdd            Op = "add"
+	OpUpdate         Op = "update"
+	OpDelete         Op = "delete"
+	OpMarkInProgress Op = "mark-in-progress"
+	OpMarkDone       Op = "mark-done"
+)
+
+// Update is the small JSON message the sync subsystem exchanges between
+// devices: it carries just enough to replay one mutation against a remote
+// JSONRepository. Task is only populated for OpAdd, so the new task keeps
+// the same ID everywhere it's applied.
+type Update struct {
+	Op          Op        `json:"op"`
+	TaskID      int       `json:"taskId,omitempty"`
+	Description string    `json:"description,omitempty"`
+	Task        *Task     `json:"task,omitempty"`
+	At          time.Time `json:"at"`
+}
+
+// ApplyUpdate merges a remote mutation into the local task set. Unlike the
+// CRUD methods it is idempotent: applying the same Update twice (as can
+// happen if a message is re-delivered) leaves the store unchanged the
+// second time.
+func (r *JSONRepository) ApplyUpdate(u Update) error {
+	switch u.Op {
+	case OpAdd:
+		if u.Task == nil {
+			return fmt.Errorf("apply update: %s requires a task", OpAdd)
+		}
+		for _, t := range r.tasks {
+			if t.ID == u.Task.ID {
+				return nil
+			}
+		}
+		r.tasks = append(r.tasks, *u.Task)
+		if u.Task.ID >= r.nextID {
+			r.nextID = u.Task.ID + 1
+		}
+	case OpUpdate:
+		for i, t := range r.tasks {
+			if t.ID == u.TaskID {
+				r.tasks[i].Description = u.Description
+				r.tasks[i].UpdatedAt = u.At
+			}
+		}
+	case OpDelete:
+		for i, t := range r.tasks {
+			if t.ID == u.TaskID {
+				r.tasks = append(r.tasks[:i], r.tasks[i+1:]...)
+				break
+			}
+		}
+	case OpMarkInProgress:
+		r.applyStatus(u.TaskID, "in-progress", u.At)
+	case OpMarkDone:
+		r.applyStatus(u.TaskID, "done", u.At)
+	default:
+		return fmt.Errorf("apply update: unknown op %q", u.Op)
+	}
+
+	return r.save()
+}
+
+func (r *JSONRepository) applyStatus(id int, status string, at time.Time) {
+	for i, t := range r.tasks {
+		if t.ID == id {
+			r.tasks[i].Status = status
+			r.tasks[i].UpdatedAt = at
+		}
+	}
+}
@@ -0,0 +1,59 @@
+// Package sync mirrors task mutations across machines using an email
+// account as the transport: a Dispatcher sends each mutation as a small
+// JSON message over SMTP to the account itself, and a RemoteRepository
+// reads unseen messages back over IMAP so they can be replayed locally.
+// SMTP has no notion of an IMAP folder, so self-sent mail lands in INBOX;
+// Folder defaults there. Set it to something else only if a server-side
+// rule (e.g. a sieve filter) actually moves task-tracker messages there.
+package sync
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/BurntSushi/toml"
+)
+
+// Config is the contents of ~/.config/task-tracker/config.toml. SMTP
+// submission and IMAPS never share a host:port (587/STARTTLS or 465 for
+// submission, 993 for IMAP), so the two are configured separately even
+// though most providers route them through the same account.
+type Config struct {
+	SMTPServer string `toml:"smtp_server"`
+	SMTPPort   int    `toml:"smtp_port"`
+	IMAPServer string `toml:"imap_server"`
+	IMAPPort   int    `toml:"imap_port"`
+	Username   string `toml:"username"`
+	Password   string `toml:"password"`
+	// Folder is the IMAP mailbox Fetch/MarkSeen operate on. It defaults to
+	// INBOX, where self-sent SMTP mail actually lands; only set it if a
+	// server-side rule routes task-tracker messages elsewhere.
+	Folder string `toml:"folder"`
+}
+
+// ConfigPath returns the default location of the sync config file.
+func ConfigPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("resolve home directory: %w", err)
+	}
+	return filepath.Join(home, ".config", "task-tracker", "config.toml"), nil
+}
+
+// LoadConfig reads and validates the sync config at path.
+func LoadConfig(path string) (*Config, error) {
+	var cfg Config
+	if _, err := toml.DecodeFile(path, &cfg); err != nil {
+		return nil, fmt.Errorf("load sync config: %w", err)
+	}
+
+	if cfg.Folder == "" {
+		cfg.Folder = "INBOX"
+	}
+	if cfg.SMTPServer == "" || cfg.IMAPServer == "" || cfg.Username == "" {
+		return nil, fmt.Errorf("load sync config: smtp_server, imap_server, and username are required")
+	}
+
+	return &cfg, nil
+}
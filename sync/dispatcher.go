@@ -0,0 +1,48 @@
+package sync
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/smtp"
+
+	"github.com/EssaAlshammri/task-tracker/storage"
+)
+
+// Dispatcher sends task mutations to the configured mailbox so other
+// devices running `task-tracker sync` pick them up.
+type Dispatcher struct {
+	cfg *Config
+}
+
+// NewDispatcher builds a Dispatcher for cfg.
+func NewDispatcher(cfg *Config) *Dispatcher {
+	return &Dispatcher{cfg: cfg}
+}
+
+// Send encodes u as JSON and mails it to the sync account, tagged with the
+// subject the remote side searches for when it later scans for unseen
+// updates.
+func (d *Dispatcher) Send(u storage.Update) error {
+	body, err := json.Marshal(u)
+	if err != nil {
+		return fmt.Errorf("encode update: %w", err)
+	}
+
+	msg := fmt.Sprintf(
+		"From: %s\r\nTo: %s\r\nSubject: %s\r\nX-Task-Tracker-Update: 1\r\nContent-Type: application/json\r\n\r\n%s",
+		d.cfg.Username, d.cfg.Username, subjectTag, body,
+	)
+
+	addr := fmt.Sprintf("%s:%d", d.cfg.SMTPServer, d.cfg.SMTPPort)
+	auth := smtp.PlainAuth("", d.cfg.Username, d.cfg.Password, d.cfg.SMTPServer)
+	if err := smtp.SendMail(addr, auth, d.cfg.Username, []string{d.cfg.Username}, []byte(msg)); err != nil {
+		return fmt.Errorf("send update: %w", err)
+	}
+
+	return nil
+}
+
+// subjectTag marks messages as task-tracker updates so the IMAP side can
+// search for them without depending on the custom header alone (some
+// providers strip unrecognized X- headers on delivery).
+const subjectTag = "[task-tracker-sync]"
@@ -0,0 +1,141 @@
+package sync
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/emersion/go-imap"
+	"github.com/emersion/go-imap/client"
+
+	"github.com/EssaAlshammri/task-tracker/storage"
+)
+
+// RemoteRepository is the transport side of sync: it knows how to fetch
+// pending mutations from wherever other devices left them, and how to mark
+// them consumed once applied locally.
+type RemoteRepository interface {
+	Fetch() ([]storage.Update, error)
+	MarkSeen() error
+}
+
+// IMAPRemote reads task-tracker update messages out of cfg.Folder, which
+// is INBOX unless a server-side rule moves them elsewhere.
+type IMAPRemote struct {
+	cfg     *Config
+	pending *imap.SeqSet
+}
+
+// NewIMAPRemote builds a RemoteRepository backed by cfg's IMAP account.
+func NewIMAPRemote(cfg *Config) *IMAPRemote {
+	return &IMAPRemote{cfg: cfg}
+}
+
+// Fetch connects to the IMAP server, selects the sync folder, and returns
+// every unseen task-tracker update. The matching message sequence numbers
+// are remembered so a later MarkSeen call flags exactly those messages.
+func (m *IMAPRemote) Fetch() ([]storage.Update, error) {
+	addr := fmt.Sprintf("%s:%d", m.cfg.IMAPServer, m.cfg.IMAPPort)
+	c, err := client.DialTLS(addr, nil)
+	if err != nil {
+		return nil, fmt.Errorf("connect to imap server: %w", err)
+	}
+	defer c.Logout()
+
+	if err := c.Login(m.cfg.Username, m.cfg.Password); err != nil {
+		return nil, fmt.Errorf("imap login: %w", err)
+	}
+
+	if _, err := c.Select(m.cfg.Folder, false); err != nil {
+		return nil, fmt.Errorf("select folder %q: %w", m.cfg.Folder, err)
+	}
+
+	criteria := imap.NewSearchCriteria()
+	criteria.WithoutFlags = []string{imap.SeenFlag}
+	criteria.Header.Add("Subject", subjectTag)
+
+	ids, err := c.Search(criteria)
+	if err != nil {
+		return nil, fmt.Errorf("search unseen updates: %w", err)
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	seqset := new(imap.SeqSet)
+	seqset.AddNum(ids...)
+	m.pending = seqset
+
+	// Peek so fetching doesn't set \Seen; MarkSeen does that once the
+	// updates have been applied.
+	section := &imap.BodySectionName{BodyPartName: imap.BodyPartName{Specifier: imap.TextSpecifier}, Peek: true}
+	messages := make(chan *imap.Message, len(ids))
+	fetchErr := make(chan error, 1)
+	go func() {
+		fetchErr <- c.Fetch(seqset, []imap.FetchItem{section.FetchItem()}, messages)
+	}()
+
+	var updates []storage.Update
+	for msg := range messages {
+		body := msg.GetBody(section)
+		if body == nil {
+			continue
+		}
+		raw, err := io.ReadAll(body)
+		if err != nil {
+			return nil, fmt.Errorf("read message body: %w", err)
+		}
+		update, err := parseUpdate(raw)
+		if err != nil {
+			return nil, err
+		}
+		updates = append(updates, update)
+	}
+	if err := <-fetchErr; err != nil {
+		return nil, fmt.Errorf("fetch updates: %w", err)
+	}
+
+	return updates, nil
+}
+
+// parseUpdate decodes the JSON update from a message's TEXT part (the
+// fetch above asks IMAP for TEXT specifically, so raw never includes the
+// RFC 5322 headers Dispatcher.Send wrote ahead of the JSON payload).
+func parseUpdate(raw []byte) (storage.Update, error) {
+	var u storage.Update
+	if err := json.Unmarshal(raw, &u); err != nil {
+		return storage.Update{}, fmt.Errorf("decode update: %w", err)
+	}
+	return u, nil
+}
+
+// MarkSeen flags the messages returned by the last Fetch call as seen so
+// they aren't reapplied on the next sync.
+func (m *IMAPRemote) MarkSeen() error {
+	if m.pending == nil {
+		return nil
+	}
+
+	addr := fmt.Sprintf("%s:%d", m.cfg.IMAPServer, m.cfg.IMAPPort)
+	c, err := client.DialTLS(addr, nil)
+	if err != nil {
+		return fmt.Errorf("connect to imap server: %w", err)
+	}
+	defer c.Logout()
+
+	if err := c.Login(m.cfg.Username, m.cfg.Password); err != nil {
+		return fmt.Errorf("imap login: %w", err)
+	}
+	if _, err := c.Select(m.cfg.Folder, false); err != nil {
+		return fmt.Errorf("select folder %q: %w", m.cfg.Folder, err)
+	}
+
+	item := imap.FormatFlagsOp(imap.AddFlags, true)
+	flags := []any{imap.SeenFlag}
+	if err := c.Store(m.pending, item, flags, nil); err != nil {
+		return fmt.Errorf("mark updates seen: %w", err)
+	}
+
+	m.pending = nil
+	return nil
+}
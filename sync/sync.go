@@ -0,0 +1,28 @@
+package sync
+
+import (
+	"fmt"
+
+	"github.com/EssaAlshammri/task-tracker/storage"
+)
+
+// Sync pulls pending updates from remote, applies each to repo in order,
+// and marks them seen. It returns the number of updates applied.
+func Sync(repo *storage.JSONRepository, remote RemoteRepository) (int, error) {
+	updates, err := remote.Fetch()
+	if err != nil {
+		return 0, fmt.Errorf("fetch remote updates: %w", err)
+	}
+
+	for _, u := range updates {
+		if err := repo.ApplyUpdate(u); err != nil {
+			return 0, fmt.Errorf("apply update: %w", err)
+		}
+	}
+
+	if err := remote.MarkSeen(); err != nil {
+		return len(updates), fmt.Errorf("mark updates seen: %w", err)
+	}
+
+	return len(updates), nil
+}